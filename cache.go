@@ -0,0 +1,330 @@
+package clarifai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL is used for entries cached via WithCache.
+const defaultCacheTTL = time.Hour
+
+// Cache stores and retrieves raw Tag responses keyed by a stable hash of
+// {endpoint, model, url, local_id}, so repeated calls against the same
+// URL/model pair can skip the network. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, val []byte, ttl time.Duration)
+}
+
+type memoryCacheEntry struct {
+	val    []byte
+	expiry time.Time
+}
+
+// MemoryCache is an in-memory Cache. Entries are evicted lazily: a Get past
+// its ttl is treated as a miss.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+// NewMemoryCache returns an empty, ready to use MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]memoryCacheEntry{}}
+}
+
+// Get returns the cached value for key, or false if absent or expired.
+func (cache *MemoryCache) Get(key string) ([]byte, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok || time.Now().After(entry.expiry) {
+		return nil, false
+	}
+	return entry.val, true
+}
+
+// Set stores val under key until ttl elapses.
+func (cache *MemoryCache) Set(key string, val []byte, ttl time.Duration) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries[key] = memoryCacheEntry{val: val, expiry: time.Now().Add(ttl)}
+}
+
+// FileCache is a filesystem-backed Cache, storing one file per key under Dir.
+type FileCache struct {
+	Dir string
+}
+
+// NewFileCache returns a FileCache rooted at dir, creating it if necessary.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileCache{Dir: dir}, nil
+}
+
+type fileCacheEntry struct {
+	Val    []byte    `json:"val"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// Get returns the cached value for key, or false if absent or expired.
+func (cache *FileCache) Get(key string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(filepath.Join(cache.Dir, key))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := new(fileCacheEntry)
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, false
+	}
+	if time.Now().After(entry.Expiry) {
+		return nil, false
+	}
+
+	return entry.Val, true
+}
+
+// Set stores val under key until ttl elapses.
+func (cache *FileCache) Set(key string, val []byte, ttl time.Duration) {
+	data, err := json.Marshal(fileCacheEntry{Val: val, Expiry: time.Now().Add(ttl)})
+	if err != nil {
+		return
+	}
+	ioutil.WriteFile(filepath.Join(cache.Dir, key), data, 0600)
+}
+
+// WithCache wires cache into client so ImageTag/VideoTag results are cached
+// and re-served without a network round trip.
+func (client *Client) WithCache(cache Cache) *Client {
+	client.cache = cache
+	client.cacheTTL = defaultCacheTTL
+	return client
+}
+
+// cacheKey returns a stable key for the given endpoint/model/url/local_id
+// combination, so different models tagging the same URL don't collide.
+func cacheKey(endpoint, model, url, localID string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + model + "\x00" + url + "\x00" + localID))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheIndex tracks which cache keys were populated for a given URL, so
+// InvalidateURL can expire them without the Cache interface needing a
+// Delete method.
+type cacheIndex struct {
+	mu   sync.Mutex
+	keys map[string][]string
+}
+
+func (index *cacheIndex) record(url, key string) {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	if index.keys == nil {
+		index.keys = map[string][]string{}
+	}
+	index.keys[url] = append(index.keys[url], key)
+}
+
+func (index *cacheIndex) take(url string) []string {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	keys := index.keys[url]
+	delete(index.keys, url)
+	return keys
+}
+
+// InvalidateURL drops the cached Tag results that this Client stored for
+// url, across all models, so a subsequent ImageTag/VideoTag call fetches
+// fresh data. Typical use is after sending Feedback that adds or removes
+// tags for url. Entries this Client did not store itself (for example ones
+// left in a FileCache by an earlier process) are not dropped.
+func (client *Client) InvalidateURL(url string) {
+	if client.cache == nil {
+		return
+	}
+	for _, key := range client.cacheIndex.take(url) {
+		client.cache.Set(key, nil, -time.Second)
+	}
+}
+
+func (client *Client) imageTagCached(req TagRequest) (*ImageTagResp, error) {
+	merged := make([]ImageTagResult, len(req.URLs))
+	resolved := make([]bool, len(req.URLs))
+	keys := make([]string, len(req.URLs))
+	var missing TagRequest
+	var missingIdx []int
+
+	for i, url := range req.URLs {
+		localID := localIDAt(req.LocalIDs, i)
+		key := cacheKey("image-tag", req.Model, url, localID)
+		keys[i] = key
+
+		if data, ok := client.cache.Get(key); ok {
+			var result ImageTagResult
+			if err := json.Unmarshal(data, &result); err == nil {
+				merged[i] = result
+				resolved[i] = true
+				continue
+			}
+		}
+
+		missing.URLs = append(missing.URLs, url)
+		if req.LocalIDs != nil {
+			missing.LocalIDs = append(missing.LocalIDs, localID)
+		}
+		missingIdx = append(missingIdx, i)
+	}
+
+	resp := new(ImageTagResp)
+
+	if len(missing.URLs) > 0 {
+		missing.Model = req.Model
+		netResp, netErr := client.imageTagBatched(missing)
+
+		// A batch-level netErr doesn't mean every missing URL failed: match
+		// results back to their URL so cache hits and whatever succeeded
+		// over the network are never discarded because of a sibling batch's
+		// failure.
+		if netResp != nil {
+			resp.BaseTagResp = netResp.BaseTagResp
+
+			byURL := make(map[string]ImageTagResult, len(netResp.Results))
+			for _, result := range netResp.Results {
+				byURL[result.URL] = result
+			}
+
+			for _, i := range missingIdx {
+				result, ok := byURL[req.URLs[i]]
+				if !ok {
+					continue
+				}
+				merged[i] = result
+				resolved[i] = true
+
+				if data, err := json.Marshal(result); err == nil {
+					client.cache.Set(keys[i], data, client.cacheTTL)
+					client.cacheIndex.record(req.URLs[i], keys[i])
+				}
+			}
+		}
+
+		if netErr != nil {
+			var batchErrs batchErrors
+			for _, i := range missingIdx {
+				if !resolved[i] {
+					batchErrs = append(batchErrs, &batchError{Index: i, URL: req.URLs[i], Err: netErr})
+				}
+			}
+
+			resp.Results = merged
+			if len(batchErrs) > 0 {
+				return resp, batchErrs
+			}
+			return resp, nil
+		}
+	}
+
+	resp.Results = merged
+	return resp, nil
+}
+
+func (client *Client) videoTagCached(req TagRequest) (*VideoTagResp, error) {
+	merged := make([]VideoTagResult, len(req.URLs))
+	resolved := make([]bool, len(req.URLs))
+	keys := make([]string, len(req.URLs))
+	var missing TagRequest
+	var missingIdx []int
+
+	for i, url := range req.URLs {
+		localID := localIDAt(req.LocalIDs, i)
+		key := cacheKey("video-tag", req.Model, url, localID)
+		keys[i] = key
+
+		if data, ok := client.cache.Get(key); ok {
+			var result VideoTagResult
+			if err := json.Unmarshal(data, &result); err == nil {
+				merged[i] = result
+				resolved[i] = true
+				continue
+			}
+		}
+
+		missing.URLs = append(missing.URLs, url)
+		if req.LocalIDs != nil {
+			missing.LocalIDs = append(missing.LocalIDs, localID)
+		}
+		missingIdx = append(missingIdx, i)
+	}
+
+	resp := new(VideoTagResp)
+
+	if len(missing.URLs) > 0 {
+		missing.Model = req.Model
+		netResp, netErr := client.videoTagBatched(missing)
+
+		// A batch-level netErr doesn't mean every missing URL failed: match
+		// results back to their URL so cache hits and whatever succeeded
+		// over the network are never discarded because of a sibling batch's
+		// failure.
+		if netResp != nil {
+			resp.BaseTagResp = netResp.BaseTagResp
+
+			byURL := make(map[string]VideoTagResult, len(netResp.Results))
+			for _, result := range netResp.Results {
+				byURL[result.URL] = result
+			}
+
+			for _, i := range missingIdx {
+				result, ok := byURL[req.URLs[i]]
+				if !ok {
+					continue
+				}
+				merged[i] = result
+				resolved[i] = true
+
+				if data, err := json.Marshal(result); err == nil {
+					client.cache.Set(keys[i], data, client.cacheTTL)
+					client.cacheIndex.record(req.URLs[i], keys[i])
+				}
+			}
+		}
+
+		if netErr != nil {
+			var batchErrs batchErrors
+			for _, i := range missingIdx {
+				if !resolved[i] {
+					batchErrs = append(batchErrs, &batchError{Index: i, URL: req.URLs[i], Err: netErr})
+				}
+			}
+
+			resp.Results = merged
+			if len(batchErrs) > 0 {
+				return resp, batchErrs
+			}
+			return resp, nil
+		}
+	}
+
+	resp.Results = merged
+	return resp, nil
+}
+
+// localIDAt returns localIDs[i], or "" if localIDs has no entry at i.
+func localIDAt(localIDs []string, i int) string {
+	if i < len(localIDs) {
+		return localIDs[i]
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestImageTagCachedPreservesCacheHitsOnNetworkError reproduces a regression
+// where a network error fetching the uncached URLs discarded every result,
+// including URLs that were already served from the cache.
+func TestImageTagCachedPreservesCacheHitsOnNetworkError(t *testing.T) {
+	client := NewClient("id", "secret")
+
+	// A server that's immediately closed: connections to it are refused, so
+	// any attempt to reach the network (including the token fetch) fails.
+	server := httptest.NewServer(nil)
+	server.Close()
+	client.APIRoot = server.URL
+
+	cache := NewMemoryCache()
+	client.WithCache(cache)
+
+	cachedURL := "http://example.com/cached.jpg"
+	missingURL := "http://example.com/missing.jpg"
+
+	var cachedResult ImageTagResult
+	cachedResult.URL = cachedURL
+	data, err := json.Marshal(cachedResult)
+	if err != nil {
+		t.Fatalf("failed to marshal fixture result: %v", err)
+	}
+	cache.Set(cacheKey("image-tag", "", cachedURL, ""), data, time.Hour)
+
+	resp, err := client.ImageTag(TagRequest{URLs: []string{cachedURL, missingURL}})
+	if resp == nil {
+		t.Fatal("ImageTag returned a nil response; the cache hit should have survived the network failure")
+	}
+	if err == nil {
+		t.Fatal("expected an error reporting the failed network fetch for the uncached URL")
+	}
+
+	if len(resp.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(resp.Results))
+	}
+	if resp.Results[0].URL != cachedURL {
+		t.Fatalf("cache hit for %q was lost: got %+v", cachedURL, resp.Results[0])
+	}
+}
+
+// TestImageAndVideoTagCacheKeysDontCollide reproduces a regression where
+// imageTagCached and videoTagCached built their cache key from the same
+// "tag" endpoint literal, so a cached ImageTag result for a URL was served
+// back (and unmarshaled without error, since an empty result decodes
+// cleanly into either shape) to a VideoTag call for that same URL.
+func TestImageAndVideoTagCacheKeysDontCollide(t *testing.T) {
+	client := NewClient("id", "secret")
+
+	// A server that's immediately closed: connections to it are refused, so
+	// if VideoTag doesn't find a cache hit, it has to reach the network and
+	// fail instead of silently succeeding with the image cache's entry.
+	server := httptest.NewServer(nil)
+	server.Close()
+	client.APIRoot = server.URL
+
+	cache := NewMemoryCache()
+	client.WithCache(cache)
+
+	url := "http://example.com/shared.jpg"
+
+	var imageResult ImageTagResult
+	imageResult.URL = url
+	data, err := json.Marshal(imageResult)
+	if err != nil {
+		t.Fatalf("failed to marshal fixture result: %v", err)
+	}
+	cache.Set(cacheKey("image-tag", "", url, ""), data, time.Hour)
+
+	if _, err := client.VideoTag(TagRequest{URLs: []string{url}}); err == nil {
+		t.Fatal("VideoTag was served the ImageTag cache entry instead of hitting the network")
+	}
+}
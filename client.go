@@ -0,0 +1,142 @@
+package clarifai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// maxTooManyRequestsRetries bounds how many times a 429 response is honored
+// before doRequest gives up and returns it to the caller.
+const maxTooManyRequestsRetries = 5
+
+// DefaultAPIRoot is the base URL for the Clarifai v1 API
+const DefaultAPIRoot = "https://api.clarifai.com/v1"
+
+// Client is the entry point for all calls against the Clarifai API
+type Client struct {
+	ClientID     string
+	ClientSecret string
+	APIRoot      string
+	Model        string
+
+	tokens    *TokenSource
+	limiter   *rateLimiter
+	batchSize int
+
+	cache      Cache
+	cacheTTL   time.Duration
+	cacheIndex cacheIndex
+}
+
+// NewClient builds a Client authenticated with the given OAuth client credentials
+func NewClient(clientID, clientSecret string) *Client {
+	client := &Client{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		APIRoot:      DefaultAPIRoot,
+		Model:        "default",
+	}
+	client.tokens = NewTokenSource(client, nil)
+
+	return client
+}
+
+// commonHTTPRequest sends req (marshaled as JSON when non-nil) to the given
+// Clarifai endpoint and returns the raw response body. authenticated
+// requests carry an "Authorization: Bearer ..." header sourced from the
+// client's TokenSource.
+func (client *Client) commonHTTPRequest(req interface{}, endpoint string, method string, authenticated bool) ([]byte, error) {
+	var body []byte
+	var err error
+
+	if req != nil {
+		body, err = json.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	url := fmt.Sprintf("%s/%s/", client.APIRoot, endpoint)
+
+	client.throttle()
+
+	return client.doRequest(method, url, body, authenticated, false, 0)
+}
+
+// doRequest performs an HTTP request against url, attaching a bearer token
+// when authenticated is true. On a single 401, the token is force-refreshed
+// and the request is retried exactly once. On a 429, the request is
+// re-queued after the server's Retry-After delay, up to
+// maxTooManyRequestsRetries times.
+func (client *Client) doRequest(method, url string, body []byte, authenticated bool, retried bool, retries429 int) ([]byte, error) {
+	httpReq, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if authenticated {
+		token, err := client.tokens.Token()
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized && authenticated && !retried {
+		if _, err := client.tokens.Refresh(); err != nil {
+			return nil, err
+		}
+		return client.doRequest(method, url, body, authenticated, true, retries429)
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests && retries429 < maxTooManyRequestsRetries {
+		time.Sleep(retryAfter(res.Header.Get("Retry-After")))
+		return client.doRequest(method, url, body, authenticated, retried, retries429+1)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+// multipartRequest streams body (typically a multipart.Writer's pipe reader)
+// to the given endpoint with the supplied content type. Unlike
+// commonHTTPRequest, this is a single attempt: body is consumed as it is
+// sent, so a 401/429 can't be retried without re-reading the source files.
+func (client *Client) multipartRequest(body io.Reader, contentType, endpoint, method string) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s/", client.APIRoot, endpoint)
+
+	httpReq, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+
+	token, err := client.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	client.throttle()
+
+	res, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return ioutil.ReadAll(res.Body)
+}
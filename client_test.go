@@ -0,0 +1,67 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCommonHTTPRequestAttachesBearerToken(t *testing.T) {
+	var gotAuth string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Token{AccessToken: "tok123", ExpiresIn: 3600})
+	})
+	mux.HandleFunc("/info/", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(InfoResp{StatusCode: "OK"})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient("id", "secret")
+	client.APIRoot = server.URL
+
+	if _, err := client.Info(); err != nil {
+		t.Fatalf("Info() returned error: %v", err)
+	}
+
+	if gotAuth != "Bearer tok123" {
+		t.Fatalf("Info() sent Authorization %q, want %q", gotAuth, "Bearer tok123")
+	}
+}
+
+func TestDoRequestRefreshesAndRetriesOn401(t *testing.T) {
+	tokenCalls := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token/", func(w http.ResponseWriter, r *http.Request) {
+		tokenCalls++
+		json.NewEncoder(w).Encode(Token{AccessToken: fmt.Sprintf("tok-%d", tokenCalls), ExpiresIn: 3600})
+	})
+	mux.HandleFunc("/info/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer tok-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(InfoResp{StatusCode: "OK"})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient("id", "secret")
+	client.APIRoot = server.URL
+
+	if _, err := client.Info(); err != nil {
+		t.Fatalf("Info() returned error: %v", err)
+	}
+
+	if tokenCalls != 2 {
+		t.Fatalf("expected exactly one forced refresh (2 token fetches total), got %d", tokenCalls)
+	}
+}
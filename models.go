@@ -0,0 +1,143 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// BoundingBox locates a detected region within an image, expressed as
+// fractions of the image's width/height (0 to 1).
+type BoundingBox struct {
+	TopRow    float32 `json:"top_row"`
+	LeftCol   float32 `json:"left_col"`
+	BottomRow float32 `json:"bottom_row"`
+	RightCol  float32 `json:"right_col"`
+}
+
+// ColorResult is a single dominant color reported by the "color" model.
+type ColorResult struct {
+	Hex     string  `json:"hex"`
+	Density float32 `json:"density"`
+	W3CName string  `json:"w3c"`
+}
+
+// ImageColorResult is the per-image result shape for the "color" model.
+type ImageColorResult struct {
+	BaseTagResult
+	Result struct {
+		Colors []ColorResult `json:"colors"`
+	} `json:"result"`
+}
+
+// ImageColorResp is the expected JSON response from /tag/ when Model is "color"
+type ImageColorResp struct {
+	BaseTagResp
+	Results []ImageColorResult
+}
+
+// FaceResult is a single detected face reported by the "face-v1.3" model.
+type FaceResult struct {
+	BoundingBox BoundingBox `json:"bounding_box"`
+	Probability float32     `json:"probability"`
+}
+
+// ImageFacesResult is the per-image result shape for the "face-v1.3" model.
+type ImageFacesResult struct {
+	BaseTagResult
+	Result struct {
+		Faces []FaceResult `json:"face"`
+	} `json:"result"`
+}
+
+// ImageFacesResp is the expected JSON response from /tag/ when Model is "face-v1.3"
+type ImageFacesResp struct {
+	BaseTagResp
+	Results []ImageFacesResult
+}
+
+// ConceptProb is a named concept with its predicted probability, as used by
+// the demographics model's age/gender/multicultural appearance lists.
+type ConceptProb struct {
+	ConceptID string  `json:"concept_id"`
+	Name      string  `json:"name"`
+	Value     float32 `json:"value"`
+}
+
+// DemographicsResult is a single detected face's demographic predictions,
+// reported by the "demographics" model.
+type DemographicsResult struct {
+	BoundingBox             BoundingBox   `json:"bounding_box"`
+	AgeAppearance           []ConceptProb `json:"age_appearance"`
+	GenderAppearance        []ConceptProb `json:"gender_appearance"`
+	MulticulturalAppearance []ConceptProb `json:"multicultural_appearance"`
+}
+
+// ImageDemographicsResult is the per-image result shape for the
+// "demographics" model.
+type ImageDemographicsResult struct {
+	BaseTagResult
+	Result struct {
+		Faces []DemographicsResult `json:"face"`
+	} `json:"result"`
+}
+
+// ImageDemographicsResp is the expected JSON response from /tag/ when Model is "demographics"
+type ImageDemographicsResp struct {
+	BaseTagResp
+	Results []ImageDemographicsResult
+}
+
+// ImageColor requests the dominant colors of each image, via the "color" model.
+func (client *Client) ImageColor(req TagRequest) (*ImageColorResp, error) {
+	if len(req.URLs) < 1 {
+		return nil, errors.New("Requires at least one url")
+	}
+	req.Model = "color"
+
+	res, err := client.commonHTTPRequest(req, "tag", "POST", true)
+	if err != nil {
+		return nil, err
+	}
+
+	colorres := new(ImageColorResp)
+	err = json.Unmarshal(res, colorres)
+
+	return colorres, err
+}
+
+// ImageFaces detects faces in each image, via the "face-v1.3" model.
+func (client *Client) ImageFaces(req TagRequest) (*ImageFacesResp, error) {
+	if len(req.URLs) < 1 {
+		return nil, errors.New("Requires at least one url")
+	}
+	req.Model = "face-v1.3"
+
+	res, err := client.commonHTTPRequest(req, "tag", "POST", true)
+	if err != nil {
+		return nil, err
+	}
+
+	facesres := new(ImageFacesResp)
+	err = json.Unmarshal(res, facesres)
+
+	return facesres, err
+}
+
+// ImageDemographics predicts age, gender and multicultural appearance for
+// each detected face, via the "demographics" model.
+func (client *Client) ImageDemographics(req TagRequest) (*ImageDemographicsResp, error) {
+	if len(req.URLs) < 1 {
+		return nil, errors.New("Requires at least one url")
+	}
+	req.Model = "demographics"
+
+	res, err := client.commonHTTPRequest(req, "tag", "POST", true)
+	if err != nil {
+		return nil, err
+	}
+
+	demores := new(ImageDemographicsResp)
+	err = json.Unmarshal(res, demores)
+
+	return demores, err
+}
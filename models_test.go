@@ -0,0 +1,119 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newModelTestClient(t *testing.T, gotModel *string, body interface{}) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Token{AccessToken: "tok", ExpiresIn: 3600})
+	})
+	mux.HandleFunc("/tag/", func(w http.ResponseWriter, r *http.Request) {
+		var req TagRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		*gotModel = req.Model
+		json.NewEncoder(w).Encode(body)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient("id", "secret")
+	client.APIRoot = server.URL
+	return client
+}
+
+func TestImageColor(t *testing.T) {
+	var gotModel string
+	fixture := ImageColorResp{Results: []ImageColorResult{{
+		Result: struct {
+			Colors []ColorResult `json:"colors"`
+		}{Colors: []ColorResult{{Hex: "#ff0000", Density: 0.5, W3CName: "red"}}},
+	}}}
+
+	client := newModelTestClient(t, &gotModel, fixture)
+
+	resp, err := client.ImageColor(TagRequest{URLs: []string{"http://example.com/a.jpg"}})
+	if err != nil {
+		t.Fatalf("ImageColor returned error: %v", err)
+	}
+	if gotModel != "color" {
+		t.Fatalf("request Model = %q, want %q", gotModel, "color")
+	}
+
+	if len(resp.Results) != 1 || len(resp.Results[0].Result.Colors) != 1 {
+		t.Fatalf("unexpected result shape: %+v", resp)
+	}
+	if got := resp.Results[0].Result.Colors[0]; got.Hex != "#ff0000" || got.W3CName != "red" {
+		t.Fatalf("unexpected color: %+v", got)
+	}
+}
+
+func TestImageFaces(t *testing.T) {
+	var gotModel string
+	fixture := ImageFacesResp{Results: []ImageFacesResult{{
+		Result: struct {
+			Faces []FaceResult `json:"face"`
+		}{Faces: []FaceResult{{
+			BoundingBox: BoundingBox{TopRow: 0.1, LeftCol: 0.2, BottomRow: 0.3, RightCol: 0.4},
+			Probability: 0.99,
+		}}},
+	}}}
+
+	client := newModelTestClient(t, &gotModel, fixture)
+
+	resp, err := client.ImageFaces(TagRequest{URLs: []string{"http://example.com/a.jpg"}})
+	if err != nil {
+		t.Fatalf("ImageFaces returned error: %v", err)
+	}
+	if gotModel != "face-v1.3" {
+		t.Fatalf("request Model = %q, want %q", gotModel, "face-v1.3")
+	}
+
+	if len(resp.Results) != 1 || len(resp.Results[0].Result.Faces) != 1 {
+		t.Fatalf("unexpected result shape: %+v", resp)
+	}
+	if got := resp.Results[0].Result.Faces[0]; got.Probability != 0.99 || got.BoundingBox.RightCol != 0.4 {
+		t.Fatalf("unexpected face: %+v", got)
+	}
+}
+
+func TestImageDemographics(t *testing.T) {
+	var gotModel string
+	fixture := ImageDemographicsResp{Results: []ImageDemographicsResult{{
+		Result: struct {
+			Faces []DemographicsResult `json:"face"`
+		}{Faces: []DemographicsResult{{
+			BoundingBox:      BoundingBox{TopRow: 0.1, LeftCol: 0.2, BottomRow: 0.3, RightCol: 0.4},
+			AgeAppearance:    []ConceptProb{{ConceptID: "age1", Name: "20-30", Value: 0.8}},
+			GenderAppearance: []ConceptProb{{ConceptID: "gender1", Name: "feminine", Value: 0.7}},
+		}}},
+	}}}
+
+	client := newModelTestClient(t, &gotModel, fixture)
+
+	resp, err := client.ImageDemographics(TagRequest{URLs: []string{"http://example.com/a.jpg"}})
+	if err != nil {
+		t.Fatalf("ImageDemographics returned error: %v", err)
+	}
+	if gotModel != "demographics" {
+		t.Fatalf("request Model = %q, want %q", gotModel, "demographics")
+	}
+
+	if len(resp.Results) != 1 || len(resp.Results[0].Result.Faces) != 1 {
+		t.Fatalf("unexpected result shape: %+v", resp)
+	}
+	face := resp.Results[0].Result.Faces[0]
+	if len(face.AgeAppearance) != 1 || face.AgeAppearance[0].Name != "20-30" {
+		t.Fatalf("unexpected age appearance: %+v", face.AgeAppearance)
+	}
+	if len(face.GenderAppearance) != 1 || face.GenderAppearance[0].Name != "feminine" {
+		t.Fatalf("unexpected gender appearance: %+v", face.GenderAppearance)
+	}
+}
@@ -0,0 +1,180 @@
+package clarifai
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxBatchSize is used to split oversized URL lists when the server
+// has not yet reported its own limit via InfoResp.Results.MaxBatchSize.
+const DefaultMaxBatchSize = 128
+
+// rateLimiter is a simple token-bucket limiter used to keep Tag/Feedback
+// calls under Clarifai's per-minute quota.
+type rateLimiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	burst    float64
+	fillRate float64 // tokens per second
+	last     time.Time
+}
+
+func newRateLimiter(reqsPerMinute, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		tokens:   float64(burst),
+		burst:    float64(burst),
+		fillRate: float64(reqsPerMinute) / 60,
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a single request token is available.
+func (rl *rateLimiter) wait() {
+	for {
+		rl.mu.Lock()
+		now := time.Now()
+		rl.tokens += now.Sub(rl.last).Seconds() * rl.fillRate
+		if rl.tokens > rl.burst {
+			rl.tokens = rl.burst
+		}
+		rl.last = now
+
+		if rl.tokens >= 1 {
+			rl.tokens--
+			rl.mu.Unlock()
+			return
+		}
+
+		wait := time.Duration((1 - rl.tokens) / rl.fillRate * float64(time.Second))
+		rl.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
+
+// WithRateLimit configures client to throttle Tag/Feedback calls to at most
+// reqsPerMinute requests per minute, allowing short bursts of up to burst
+// requests. A non-positive reqsPerMinute disables rate limiting rather than
+// blocking forever.
+func (client *Client) WithRateLimit(reqsPerMinute, burst int) *Client {
+	if reqsPerMinute <= 0 {
+		client.limiter = nil
+		return client
+	}
+	client.limiter = newRateLimiter(reqsPerMinute, burst)
+	return client
+}
+
+// maxConcurrentBatches bounds how many batched Tag requests are pipelined to
+// the server at once.
+const maxConcurrentBatches = 4
+
+// runBatches calls worker(i) for each i in [0, n), running up to
+// maxConcurrentBatches of them concurrently, and blocks until all have
+// returned.
+func runBatches(n int, worker func(i int)) {
+	sem := make(chan struct{}, maxConcurrentBatches)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			worker(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// batchError associates an error with the original index of the URL that
+// caused it, so callers can tell which batch/URL failed.
+type batchError struct {
+	Index int
+	URL   string
+	Err   error
+}
+
+func (e *batchError) Error() string {
+	return fmt.Sprintf("clarifai: request for url[%d] %q failed: %v", e.Index, e.URL, e.Err)
+}
+
+// batchErrors collects the batchErrors from a split Tag request so a caller
+// can inspect which of the original URLs failed while still getting results
+// for the batches that succeeded.
+type batchErrors []*batchError
+
+func (errs batchErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("clarifai: %d url(s) failed: %s", len(errs), strings.Join(msgs, "; "))
+}
+
+// maxBatchSize returns the batch size to split oversized requests into,
+// preferring the server-reported limit when the client has one cached.
+func (client *Client) maxBatchSize() int {
+	if client.batchSize > 0 {
+		return client.batchSize
+	}
+	return DefaultMaxBatchSize
+}
+
+// throttle blocks until the client's rate limiter (if any) admits the next
+// request.
+func (client *Client) throttle() {
+	if client.limiter != nil {
+		client.limiter.wait()
+	}
+}
+
+// retryAfter parses a Retry-After header value expressed in seconds, as
+// Clarifai sends on 429 responses.
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// batchTagRequests splits req into chunks of at most size URLs, returned in
+// their original order, so each chunk's original index range follows from
+// the lengths of the chunks before it and results and errors can be
+// re-merged in order.
+func batchTagRequests(req TagRequest, size int) ([]TagRequest, error) {
+	if req.LocalIDs != nil && len(req.LocalIDs) != len(req.URLs) {
+		return nil, fmt.Errorf("clarifai: LocalIDs has %d entries but URLs has %d; they must match", len(req.LocalIDs), len(req.URLs))
+	}
+
+	if size < 1 || len(req.URLs) <= size {
+		return []TagRequest{req}, nil
+	}
+
+	var batches []TagRequest
+	for start := 0; start < len(req.URLs); start += size {
+		end := start + size
+		if end > len(req.URLs) {
+			end = len(req.URLs)
+		}
+
+		batch := TagRequest{
+			URLs:  req.URLs[start:end],
+			Model: req.Model,
+		}
+		if req.LocalIDs != nil {
+			batch.LocalIDs = req.LocalIDs[start:end]
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
@@ -0,0 +1,55 @@
+package clarifai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBatchTagRequestsRejectsMismatchedLocalIDs(t *testing.T) {
+	req := TagRequest{
+		URLs:     []string{"http://example.com/a.jpg", "http://example.com/b.jpg"},
+		LocalIDs: []string{"only-one"},
+	}
+
+	if _, err := batchTagRequests(req, 1); err == nil {
+		t.Fatal("expected an error for mismatched URLs/LocalIDs lengths, got nil (and no panic)")
+	}
+}
+
+func TestBatchTagRequestsSplitsBySize(t *testing.T) {
+	req := TagRequest{URLs: []string{"a", "b", "c", "d", "e"}}
+
+	batches, err := batchTagRequests(req, 2)
+	if err != nil {
+		t.Fatalf("batchTagRequests returned error: %v", err)
+	}
+
+	if len(batches) != 3 {
+		t.Fatalf("got %d batches, want 3", len(batches))
+	}
+	if len(batches[0].URLs) != 2 || len(batches[1].URLs) != 2 || len(batches[2].URLs) != 1 {
+		t.Fatalf("unexpected batch sizes: %v", batches)
+	}
+}
+
+func TestWithRateLimitDisablesOnNonPositiveRate(t *testing.T) {
+	client := NewClient("id", "secret")
+	client.WithRateLimit(0, 5)
+
+	if client.limiter != nil {
+		t.Fatal("WithRateLimit(0, ...) should disable the limiter, not install one with a zero fill rate")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		client.throttle()
+		client.throttle()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("throttle() blocked with rate limiting disabled")
+	}
+}
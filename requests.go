@@ -67,16 +67,33 @@ type BaseTagResult struct {
 	DocIDString   string   `json:"docid_str"`
 }
 
-// TagResult represents the expected data for a single tag result
+// ImageTagClasses is the "tag" model's result shape: a parallel set of
+// class names, category ids and probabilities.
+type ImageTagClasses struct {
+	Classes []string  `json:"classes"`
+	CatIDs  []string  `json:"catids"`
+	Probs   []float32 `json:"probs"`
+}
+
+// ImageTagResult represents the expected data for a single tag result. Result is
+// kept as a RawMessage because its shape depends on the model that produced
+// it (see Tag, and ColorResult/FaceResult/DemographicsResult for the
+// color/face-v1.3/demographics models); use Tag() to decode the default
+// "tag" model's classes.
 type ImageTagResult struct {
 	BaseTagResult
-	Result struct {
-		Tag struct {
-			Classes []string  `json:"classes"`
-			CatIDs  []string  `json:"catids"`
-			Probs   []float32 `json:"probs"`
-		}
+	Result json.RawMessage `json:"result"`
+}
+
+// Tag decodes Result as the default "tag" model's classes/catids/probs.
+func (result *ImageTagResult) Tag() (*ImageTagClasses, error) {
+	wrapper := struct {
+		Tag ImageTagClasses `json:"tag"`
+	}{}
+	if err := json.Unmarshal(result.Result, &wrapper); err != nil {
+		return nil, err
 	}
+	return &wrapper.Tag, nil
 }
 
 // TagResult represents the expected data for a single tag result
@@ -110,7 +127,7 @@ type FeedbackResp struct {
 
 // Info will return the current status info for the given client
 func (client *Client) Info() (*InfoResp, error) {
-	res, err := client.commonHTTPRequest(nil, "info", "GET", false)
+	res, err := client.commonHTTPRequest(nil, "info", "GET", true)
 
 	if err != nil {
 		return nil, err
@@ -118,6 +135,9 @@ func (client *Client) Info() (*InfoResp, error) {
 
 	info := new(InfoResp)
 	err = json.Unmarshal(res, info)
+	if err == nil {
+		client.batchSize = info.Results.MaxBatchSize
+	}
 
 	return info, err
 }
@@ -127,13 +147,63 @@ func (client *Client) Tag(req TagRequest) (*ImageTagResp, error) {
 	return client.ImageTag(req)
 }
 
-// ImageTag allows the client to request tag data on a single, or multiple photos
+// ImageTag allows the client to request tag data on a single, or multiple
+// photos. Requests larger than the server's MaxBatchSize are transparently
+// split into batches, pipelined through the client's rate limiter, and
+// merged back together in the original URL order. URLs already present in
+// the client's cache (see WithCache) are served without a network call.
 func (client *Client) ImageTag(req TagRequest) (*ImageTagResp, error) {
 	if len(req.URLs) < 1 {
 		return nil, errors.New("Requires at least one url")
 	}
 
-	res, err := client.commonHTTPRequest(req, "tag", "POST", false)
+	if client.cache != nil {
+		return client.imageTagCached(req)
+	}
+
+	return client.imageTagBatched(req)
+}
+
+func (client *Client) imageTagBatched(req TagRequest) (*ImageTagResp, error) {
+	batches, err := batchTagRequests(req, client.maxBatchSize())
+	if err != nil {
+		return nil, err
+	}
+	if len(batches) == 1 {
+		return client.imageTagOnce(batches[0])
+	}
+
+	resps := make([]*ImageTagResp, len(batches))
+	errs := make([]error, len(batches))
+	runBatches(len(batches), func(i int) {
+		resps[i], errs[i] = client.imageTagOnce(batches[i])
+	})
+
+	merged := new(ImageTagResp)
+	var batchErrs batchErrors
+	offset := 0
+	for i, resp := range resps {
+		if err := errs[i]; err != nil {
+			for j, url := range batches[i].URLs {
+				batchErrs = append(batchErrs, &batchError{Index: offset + j, URL: url, Err: err})
+			}
+		} else {
+			if i == 0 {
+				merged.BaseTagResp = resp.BaseTagResp
+			}
+			merged.Results = append(merged.Results, resp.Results...)
+		}
+		offset += len(batches[i].URLs)
+	}
+
+	if len(batchErrs) > 0 {
+		return merged, batchErrs
+	}
+	return merged, nil
+}
+
+func (client *Client) imageTagOnce(req TagRequest) (*ImageTagResp, error) {
+	res, err := client.commonHTTPRequest(req, "tag", "POST", true)
 
 	if err != nil {
 		return nil, err
@@ -145,13 +215,63 @@ func (client *Client) ImageTag(req TagRequest) (*ImageTagResp, error) {
 	return tagres, err
 }
 
-// VideoTag allows the client to request tag data on a single, or multiple videos
+// VideoTag allows the client to request tag data on a single, or multiple
+// videos. Requests larger than the server's MaxBatchSize are transparently
+// split into batches, pipelined through the client's rate limiter, and
+// merged back together in the original URL order. URLs already present in
+// the client's cache (see WithCache) are served without a network call.
 func (client *Client) VideoTag(req TagRequest) (*VideoTagResp, error) {
 	if len(req.URLs) < 1 {
 		return nil, errors.New("Requires at least one url")
 	}
 
-	res, err := client.commonHTTPRequest(req, "tag", "POST", false)
+	if client.cache != nil {
+		return client.videoTagCached(req)
+	}
+
+	return client.videoTagBatched(req)
+}
+
+func (client *Client) videoTagBatched(req TagRequest) (*VideoTagResp, error) {
+	batches, err := batchTagRequests(req, client.maxBatchSize())
+	if err != nil {
+		return nil, err
+	}
+	if len(batches) == 1 {
+		return client.videoTagOnce(batches[0])
+	}
+
+	resps := make([]*VideoTagResp, len(batches))
+	errs := make([]error, len(batches))
+	runBatches(len(batches), func(i int) {
+		resps[i], errs[i] = client.videoTagOnce(batches[i])
+	})
+
+	merged := new(VideoTagResp)
+	var batchErrs batchErrors
+	offset := 0
+	for i, resp := range resps {
+		if err := errs[i]; err != nil {
+			for j, url := range batches[i].URLs {
+				batchErrs = append(batchErrs, &batchError{Index: offset + j, URL: url, Err: err})
+			}
+		} else {
+			if i == 0 {
+				merged.BaseTagResp = resp.BaseTagResp
+			}
+			merged.Results = append(merged.Results, resp.Results...)
+		}
+		offset += len(batches[i].URLs)
+	}
+
+	if len(batchErrs) > 0 {
+		return merged, batchErrs
+	}
+	return merged, nil
+}
+
+func (client *Client) videoTagOnce(req TagRequest) (*VideoTagResp, error) {
+	res, err := client.commonHTTPRequest(req, "tag", "POST", true)
 
 	if err != nil {
 		return nil, err
@@ -173,7 +293,7 @@ func (client *Client) Feedback(form FeedbackForm) (*FeedbackResp, error) {
 		return nil, errors.New("Request must provide exactly one of the following fields: {'DocIDs', 'URLs'}")
 	}
 
-	res, err := client.commonHTTPRequest(form, "feedback", "POST", false)
+	res, err := client.commonHTTPRequest(form, "feedback", "POST", true)
 
 	feedbackres := new(FeedbackResp)
 	err = json.Unmarshal(res, feedbackres)
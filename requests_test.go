@@ -0,0 +1,73 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestImageTagBatchedReportsEveryURLInFailedBatch reproduces a regression
+// where a failed batch only recorded its first URL in the returned
+// batchErrors, silently dropping the rest of that batch's URLs from the
+// error even though none of them got a result either.
+func TestImageTagBatchedReportsEveryURLInFailedBatch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Token{AccessToken: "tok", ExpiresIn: 3600})
+	})
+	mux.HandleFunc("/tag/", func(w http.ResponseWriter, r *http.Request) {
+		var req TagRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if strings.Contains(req.URLs[0], "bad") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resp := ImageTagResp{}
+		for _, url := range req.URLs {
+			resp.Results = append(resp.Results, ImageTagResult{BaseTagResult: BaseTagResult{URL: url}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient("id", "secret")
+	client.APIRoot = server.URL
+	client.batchSize = 2
+
+	req := TagRequest{URLs: []string{
+		"http://example.com/good1.jpg", "http://example.com/good2.jpg",
+		"http://example.com/bad1.jpg", "http://example.com/bad2.jpg",
+	}}
+
+	resp, err := client.ImageTag(req)
+	if resp == nil {
+		t.Fatal("ImageTag returned a nil response; the succeeding batch's results should have survived")
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("got %d results, want 2 from the succeeding batch", len(resp.Results))
+	}
+
+	batchErrs, ok := err.(batchErrors)
+	if !ok {
+		t.Fatalf("expected a batchErrors, got %T: %v", err, err)
+	}
+	if len(batchErrs) != 2 {
+		t.Fatalf("got %d batch errors, want 2 (one per URL in the failed batch): %v", len(batchErrs), batchErrs)
+	}
+
+	gotURLs := map[string]bool{}
+	for _, batchErr := range batchErrs {
+		gotURLs[batchErr.URL] = true
+	}
+	for _, url := range []string{"http://example.com/bad1.jpg", "http://example.com/bad2.jpg"} {
+		if !gotURLs[url] {
+			t.Errorf("batchErrors is missing %q", url)
+		}
+	}
+}
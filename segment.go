@@ -0,0 +1,160 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RLEMask is a COCO-style run-length-encoded binary mask: Counts alternates
+// between runs of 0s and runs of 1s, read in column-major order over an
+// image of the given Size ([height, width]).
+type RLEMask struct {
+	Size   [2]int `json:"size"`
+	Counts string `json:"counts"`
+}
+
+// Region is a single segmented region of an image, as returned by Segment.
+type Region struct {
+	BoundingBox BoundingBox `json:"bounding_box"`
+	Mask        RLEMask     `json:"mask"`
+	Classes     []string    `json:"classes"`
+	Probs       []float32   `json:"probs"`
+}
+
+// DecodeMask inflates Region's COCO RLE mask into a binary *image.Gray (0 or
+// 255), or nil if the region carries no decodable mask.
+func (region *Region) DecodeMask() *image.Gray {
+	height, width := region.Mask.Size[0], region.Mask.Size[1]
+	if height <= 0 || width <= 0 {
+		return nil
+	}
+
+	counts, err := parseRLECounts(region.Mask.Counts)
+	if err != nil {
+		return nil
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	pos, value := 0, false
+	for _, count := range counts {
+		for i := 0; i < count && pos < width*height; i++ {
+			if value {
+				img.SetGray(pos/height, pos%height, color.Gray{Y: 255})
+			}
+			pos++
+		}
+		value = !value
+	}
+
+	return img
+}
+
+// parseRLECounts parses a COCO RLE "counts" string of whitespace/comma
+// separated run lengths.
+func parseRLECounts(counts string) ([]int, error) {
+	fields := strings.FieldsFunc(counts, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	runs := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		runs[i] = n
+	}
+
+	return runs, nil
+}
+
+// SegmentResult is the per-image result of a Segment call: a list of
+// detected regions rather than whole-image classes.
+type SegmentResult struct {
+	BaseTagResult
+	Regions []Region `json:"regions"`
+}
+
+// SegmentResp is the expected JSON response from /segment/
+type SegmentResp struct {
+	BaseTagResp
+	Results []SegmentResult
+}
+
+// SegmentRequest requests region-level segmentation for the given URLs.
+// When Fallback is true, Segment never calls /segment/: it synthesizes one
+// Region per detected face from ImageTag + ImageFaces instead. Whether the
+// upstream API supports segmentation is not checked.
+type SegmentRequest struct {
+	TagRequest
+	Fallback bool `json:"-"`
+}
+
+// Segment requests region-level results for each image, rather than the
+// whole-image classes ImageTag returns.
+func (client *Client) Segment(req SegmentRequest) (*SegmentResp, error) {
+	if len(req.URLs) < 1 {
+		return nil, errors.New("Requires at least one url")
+	}
+
+	if req.Fallback {
+		return client.segmentFallback(req.TagRequest)
+	}
+
+	res, err := client.commonHTTPRequest(req.TagRequest, "segment", "POST", true)
+	if err != nil {
+		return nil, err
+	}
+
+	segres := new(SegmentResp)
+	err = json.Unmarshal(res, segres)
+
+	return segres, err
+}
+
+// segmentFallback synthesizes a SegmentResp from ImageTag + ImageFaces, for
+// use until the upstream API supports segmentation natively. Each detected
+// face becomes a maskless Region carrying the image's overall tag classes.
+func (client *Client) segmentFallback(req TagRequest) (*SegmentResp, error) {
+	tagResp, err := client.ImageTag(req)
+	if err != nil {
+		return nil, err
+	}
+
+	facesResp, err := client.ImageFaces(req)
+	if err != nil {
+		return nil, err
+	}
+
+	segres := &SegmentResp{BaseTagResp: tagResp.BaseTagResp}
+	for i, tagResult := range tagResp.Results {
+		var classes []string
+		var probs []float32
+		if tag, err := tagResult.Tag(); err == nil {
+			classes, probs = tag.Classes, tag.Probs
+		}
+
+		var regions []Region
+		if i < len(facesResp.Results) {
+			for _, face := range facesResp.Results[i].Result.Faces {
+				regions = append(regions, Region{
+					BoundingBox: face.BoundingBox,
+					Classes:     classes,
+					Probs:       probs,
+				})
+			}
+		}
+
+		segres.Results = append(segres.Results, SegmentResult{
+			BaseTagResult: tagResult.BaseTagResult,
+			Regions:       regions,
+		})
+	}
+
+	return segres, nil
+}
@@ -0,0 +1,43 @@
+package clarifai
+
+import (
+	"image/color"
+	"testing"
+)
+
+// TestRegionDecodeMask checks the column-major, alternating-run decode
+// against a hand-traced 2x2 mask: column 0 is [0,1] (top-left unset,
+// bottom-left set), column 1 is [1,1] (both set).
+func TestRegionDecodeMask(t *testing.T) {
+	region := Region{
+		Mask: RLEMask{
+			Size:   [2]int{2, 2}, // height, width
+			Counts: "1,3",
+		},
+	}
+
+	img := region.DecodeMask()
+	if img == nil {
+		t.Fatal("DecodeMask returned nil for a valid mask")
+	}
+
+	want := [][]bool{
+		{false, true},
+		{true, true},
+	}
+	for row := 0; row < 2; row++ {
+		for col := 0; col < 2; col++ {
+			got := img.GrayAt(col, row) == color.Gray{Y: 255}
+			if got != want[row][col] {
+				t.Errorf("pixel (row=%d, col=%d) = %v, want %v", row, col, got, want[row][col])
+			}
+		}
+	}
+}
+
+func TestRegionDecodeMaskInvalidSize(t *testing.T) {
+	region := Region{Mask: RLEMask{Size: [2]int{0, 0}, Counts: "1"}}
+	if img := region.DecodeMask(); img != nil {
+		t.Fatal("DecodeMask should return nil for a zero-sized mask")
+	}
+}
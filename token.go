@@ -0,0 +1,190 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// refreshSkew is how far ahead of the reported expiry a token is considered
+// stale, so a request started just before expiry doesn't race the server.
+const refreshSkew = 60 * time.Second
+
+// Token is an OAuth access token as returned by POST /token/
+type Token struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	Scope       string    `json:"scope"`
+	ExpiresIn   int       `json:"expires_in"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+func (token *Token) expired() bool {
+	return token == nil || time.Now().After(token.Expiry.Add(-refreshSkew))
+}
+
+// TokenStore persists a Token across process restarts. Implementations must
+// be safe for concurrent use.
+type TokenStore interface {
+	Load() (*Token, error)
+	Save(token *Token) error
+}
+
+// MemoryTokenStore keeps the token in process memory and is the default
+// TokenStore used by NewTokenSource.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *Token
+}
+
+// Load returns the in-memory token, if any.
+func (store *MemoryTokenStore) Load() (*Token, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.token, nil
+}
+
+// Save replaces the in-memory token.
+func (store *MemoryTokenStore) Save(token *Token) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.token = token
+	return nil
+}
+
+// FileTokenStore persists the token as JSON at Path, so it survives process
+// restarts.
+type FileTokenStore struct {
+	Path string
+}
+
+// NewFileTokenStore returns a TokenStore that reads and writes the token as
+// JSON at path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+// Load reads the token from disk. A missing file is not an error; it simply
+// yields a nil token so the TokenSource fetches a fresh one.
+func (store *FileTokenStore) Load() (*Token, error) {
+	data, err := ioutil.ReadFile(store.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := new(Token)
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Save writes the token to disk as JSON.
+func (store *FileTokenStore) Save(token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(store.Path, data, 0600)
+}
+
+// TokenSource manages the lifecycle of the OAuth token used to authenticate
+// requests made by Client: it fetches a token from /token/, caches it via a
+// TokenStore, and transparently refreshes it before it expires.
+type TokenSource struct {
+	client *Client
+	store  TokenStore
+
+	mu sync.Mutex
+}
+
+// NewTokenSource returns a TokenSource for client. When store is nil, tokens
+// are kept in memory only.
+func NewTokenSource(client *Client, store TokenStore) *TokenSource {
+	if store == nil {
+		store = &MemoryTokenStore{}
+	}
+	return &TokenSource{client: client, store: store}
+}
+
+// Token returns a valid access token, fetching or refreshing it as needed.
+func (ts *TokenSource) Token() (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	token, err := ts.store.Load()
+	if err != nil {
+		return "", err
+	}
+
+	if token.expired() {
+		token, err = ts.fetch()
+		if err != nil {
+			return "", err
+		}
+		if err := ts.store.Save(token); err != nil {
+			return "", err
+		}
+	}
+
+	return token.AccessToken, nil
+}
+
+// Refresh forces a fresh token to be requested, regardless of the cached
+// token's expiry, and stores the result.
+func (ts *TokenSource) Refresh() (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	token, err := ts.fetch()
+	if err != nil {
+		return "", err
+	}
+	if err := ts.store.Save(token); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// fetch exchanges the client's credentials for a fresh token via POST
+// /token/ using grant_type=client_credentials.
+func (ts *TokenSource) fetch() (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {ts.client.ClientID},
+		"client_secret": {ts.client.ClientSecret},
+	}
+
+	reqURL := fmt.Sprintf("%s/token/", ts.client.APIRoot)
+	res, err := http.Post(reqURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("clarifai: token request failed with status %s: %s", res.Status, body)
+	}
+
+	token := new(Token)
+	if err := json.Unmarshal(body, token); err != nil {
+		return nil, err
+	}
+	token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return token, nil
+}
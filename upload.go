@@ -0,0 +1,113 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+)
+
+// ImageFile is a local or in-memory image to be tagged via ImageTagBytes.
+// Data is read and streamed exactly once; it is not buffered in full.
+type ImageFile struct {
+	Data     io.Reader
+	Filename string
+	LocalID  string
+}
+
+// VideoFile is a local or in-memory video to be tagged via VideoTagBytes.
+// Data is read and streamed exactly once; it is not buffered in full.
+type VideoFile struct {
+	Data     io.Reader
+	Filename string
+	LocalID  string
+}
+
+// ImageTagBytes allows the client to request tag data for local or private
+// images, supplied as readers rather than public URLs. URLs may additionally
+// be passed so a single request mixes uploaded bytes with public URLs.
+func (client *Client) ImageTagBytes(files []ImageFile, urls ...string) (*ImageTagResp, error) {
+	if len(files) < 1 && len(urls) < 1 {
+		return nil, errors.New("Requires at least one file or url")
+	}
+
+	res, err := client.tagBytes(files, urls, client.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	tagres := new(ImageTagResp)
+	err = json.Unmarshal(res, tagres)
+
+	return tagres, err
+}
+
+// VideoTagBytes allows the client to request tag data for local or private
+// videos, supplied as readers rather than public URLs. URLs may additionally
+// be passed so a single request mixes uploaded bytes with public URLs.
+func (client *Client) VideoTagBytes(files []VideoFile, urls ...string) (*VideoTagResp, error) {
+	if len(files) < 1 && len(urls) < 1 {
+		return nil, errors.New("Requires at least one file or url")
+	}
+
+	videoFiles := make([]ImageFile, len(files))
+	for i, f := range files {
+		videoFiles[i] = ImageFile(f)
+	}
+
+	res, err := client.tagBytes(videoFiles, urls, client.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	tagres := new(VideoTagResp)
+	err = json.Unmarshal(res, tagres)
+
+	return tagres, err
+}
+
+// tagBytes posts files and urls to /tag/ as multipart/form-data, streaming
+// each file's contents through the "encoded_data" field so callers never
+// need to buffer a whole image or video in memory.
+func (client *Client) tagBytes(files []ImageFile, urls []string, model string) ([]byte, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		err := func() error {
+			for _, url := range urls {
+				if err := writer.WriteField("url", url); err != nil {
+					return err
+				}
+			}
+
+			for _, file := range files {
+				if file.LocalID != "" {
+					if err := writer.WriteField("local_id", file.LocalID); err != nil {
+						return err
+					}
+				}
+
+				part, err := writer.CreateFormFile("encoded_data", file.Filename)
+				if err != nil {
+					return err
+				}
+				if _, err := io.Copy(part, file.Data); err != nil {
+					return err
+				}
+			}
+
+			if model != "" {
+				if err := writer.WriteField("model", model); err != nil {
+					return err
+				}
+			}
+
+			return writer.Close()
+		}()
+
+		pw.CloseWithError(err)
+	}()
+
+	return client.multipartRequest(pr, writer.FormDataContentType(), "tag", "POST")
+}
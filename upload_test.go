@@ -0,0 +1,88 @@
+package clarifai
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestImageTagBytesMultipartBody asserts the shape of the multipart request
+// tagBytes builds for ImageTagBytes: urls first (each as a "url" field), then
+// one part per file (an optional "local_id" field immediately before its
+// "encoded_data" part), then a trailing "model" field.
+func TestImageTagBytesMultipartBody(t *testing.T) {
+	type part struct {
+		name string
+		data string
+	}
+	var gotParts []part
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Token{AccessToken: "tok", ExpiresIn: 3600})
+	})
+	mux.HandleFunc("/tag/", func(w http.ResponseWriter, r *http.Request) {
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("failed to parse Content-Type: %v", err)
+		}
+
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			p, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to read multipart part: %v", err)
+			}
+
+			data, err := io.ReadAll(p)
+			if err != nil {
+				t.Fatalf("failed to read part %q: %v", p.FormName(), err)
+			}
+			gotParts = append(gotParts, part{name: p.FormName(), data: string(data)})
+		}
+
+		json.NewEncoder(w).Encode(ImageTagResp{})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient("id", "secret")
+	client.APIRoot = server.URL
+	client.Model = "default"
+
+	files := []ImageFile{
+		{Data: strings.NewReader("first-bytes"), Filename: "first.jpg", LocalID: "local-1"},
+		{Data: strings.NewReader("second-bytes"), Filename: "second.jpg"},
+	}
+
+	if _, err := client.ImageTagBytes(files, "http://example.com/a.jpg", "http://example.com/b.jpg"); err != nil {
+		t.Fatalf("ImageTagBytes returned error: %v", err)
+	}
+
+	want := []part{
+		{name: "url", data: "http://example.com/a.jpg"},
+		{name: "url", data: "http://example.com/b.jpg"},
+		{name: "local_id", data: "local-1"},
+		{name: "encoded_data", data: "first-bytes"},
+		{name: "encoded_data", data: "second-bytes"},
+		{name: "model", data: "default"},
+	}
+
+	if len(gotParts) != len(want) {
+		t.Fatalf("got %d parts, want %d: %+v", len(gotParts), len(want), gotParts)
+	}
+	for i, w := range want {
+		if gotParts[i] != w {
+			t.Errorf("part[%d] = %+v, want %+v", i, gotParts[i], w)
+		}
+	}
+}